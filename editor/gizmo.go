@@ -4,7 +4,7 @@
 package editor
 
 import (
-	"fmt"
+	"math"
 
 	mgl "github.com/go-gl/mathgl/mgl32"
 	"github.com/tbogdala/fizzle"
@@ -18,68 +18,499 @@ const (
 	axisDirX = 0
 	axisDirY = 1
 	axisDirZ = 2
+
+	// axisDirXY, axisDirXZ and axisDirYZ are reported when one of the
+	// plane handles is picked in ModeTranslate, so a drag can be
+	// constrained to that plane instead of a single axis.
+	axisDirXY = 3
+	axisDirXZ = 4
+	axisDirYZ = 5
+
+	// axisDirUniform is the pseudo-axis reported when the center handle of
+	// the scale gizmo is picked; it scales all three axes together. Like
+	// the plane handles above, it reuses an index that only has meaning
+	// within its own mode's collider list.
+	axisDirUniform = 3
+)
+
+// Extents of the three plane handles drawn near the origin of the
+// translate gizmo, shared between buildRenderables and
+// generateTranslateColliders so the pickable region lines up with what's
+// drawn.
+const (
+	planeHandleNear      = float32(0.1)
+	planeHandleFar       = float32(0.3)
+	planeHandleThickness = float32(0.005)
+)
+
+// GizmoMode identifies which transform operation the gizmo is currently
+// configured to perform.
+type GizmoMode int
+
+// The transform operations the gizmo can be switched between with SetMode.
+const (
+	ModeTranslate GizmoMode = iota
+	ModeRotate
+	ModeScale
 )
 
+// GizmoSpace selects whether the gizmo's axes, and the picking and drag math
+// built on them, align with the world axes or with the active component's
+// own orientation.
+type GizmoSpace int
+
+// The spaces a Gizmo can be switched between with SetSpace.
+const (
+	// SpaceWorld aligns the gizmo with the world axes, regardless of any
+	// selected component's orientation.
+	SpaceWorld GizmoSpace = iota
+
+	// SpaceLocal aligns the gizmo with Selection.Active's orientation
+	// quaternion, falling back to SpaceWorld if there is no active
+	// component.
+	SpaceLocal
+)
+
+// PivotMode selects how a multi-component Selection's shared pivot point is
+// computed, and how rotate/scale deltas are distributed across the
+// components in that selection.
+type PivotMode int
+
+// The pivot placements a Selection can be switched between.
+const (
+	// PivotMedian places the pivot at the average location of every
+	// component in the selection; rotate/scale orbit that shared point.
+	PivotMedian PivotMode = iota
+
+	// PivotActive places the pivot at Selection.Active's location;
+	// rotate/scale orbit that point.
+	PivotActive
+
+	// PivotIndividualOrigins leaves each component's own location as its
+	// pivot; rotate/scale are applied to it in place, with no orbiting.
+	PivotIndividualOrigins
+)
+
+// Selection is the set of components the gizmo currently operates on. The
+// gizmo is drawn at the pivot computed from Components according to Pivot,
+// and OnLMBDown/OnLMBUp apply the same delta transform to every component in
+// the set.
+type Selection struct {
+	// Components is every component currently selected.
+	Components []*component.Component
+
+	// Active is the primary component of the selection — typically the
+	// most recently clicked one — used as the pivot when Pivot is
+	// PivotActive.
+	Active *component.Component
+
+	// Pivot selects how the shared pivot point is computed from Components.
+	Pivot PivotMode
+}
+
+// componentStart snapshots one selected component's transform at pick time,
+// so a drag can always compute its delta relative to the start.
+type componentStart struct {
+	component   *component.Component
+	location    mgl.Vec3
+	orientation mgl.Quat
+	scale       mgl.Vec3
+}
+
 // Gizmo is the transform gizmo that can be drawn in the editor.
 type Gizmo struct {
 	// Gizmo is the drawable gizmo object for the current operation.
 	Gizmo *scene.VisibleEntity
 
+	// SnapActive is toggled by the owning editor (e.g. while a modifier
+	// key such as Shift is held) to enable grid/angle/scale snapping for
+	// the duration of a drag.
+	SnapActive bool
+
+	// TranslateSnap, RotateSnap (radians) and ScaleSnap are the snap
+	// increments used while SnapActive is true. Set them with SetSnaps;
+	// a value of 0 disables snapping on that channel.
+	TranslateSnap float32
+	RotateSnap    float32
+	ScaleSnap     float32
+
+	// SnapTicks is a renderable of faint tick marks along the axis being
+	// dragged, spaced at TranslateSnap intervals. It's rebuilt on pick and
+	// cleared on release; nil whenever there is nothing to draw.
+	SnapTicks *fizzle.Renderable
+
 	translate *fizzle.Renderable
 	scale     *fizzle.Renderable
 	rotate    *fizzle.Renderable
+	shader    *fizzle.RenderShader
+
+	// mode selects which of translate/scale/rotate is currently active.
+	mode GizmoMode
 
 	// the last scale used while generating the gizmo
 	lastScale float32
 
-	mouseDown     bool // is the mouse considered to be down for event handling
-	lastMouseX    float32
-	lastMouseY    float32
-	axisDir       int // the direction to apply the transform; use axisDirX || axisDirY || axisDirZ
-	transformFunc func()
+	// selection is the set of components the gizmo currently operates on,
+	// assigned through SetSelection.
+	selection *Selection
+
+	// pivot is the point, recomputed from selection by RecomputePivot,
+	// that the gizmo is drawn at and that rotate/scale deltas orbit around
+	// for PivotMedian/PivotActive.
+	pivot mgl.Vec3
+
+	// space selects whether the gizmo's axes align with the world or with
+	// the active component's orientation, set through SetSpace.
+	space GizmoSpace
+
+	mouseDown  bool // is the mouse considered to be down for event handling
+	lastMouseX float32
+	lastMouseY float32
+	axisDir    int // the direction to apply the transform; use axisDirX || axisDirY || axisDirZ, axisDirXY || axisDirXZ || axisDirYZ in ModeTranslate, or axisDirUniform in ModeScale
+
+	// lastHitVec is the previous frame's ray/rotation-plane hit point,
+	// relative to the pivot, used to derive the incremental angle while
+	// dragging in ModeRotate.
+	lastHitVec mgl.Vec3
+
+	// pickOffset is the world-space offset between the point initially
+	// grabbed on the axis line or plane and the pivot, captured on pick so
+	// the grabbed point stays under the cursor for the rest of the drag in
+	// ModeTranslate.
+	pickOffset mgl.Vec3
+
+	// dragStarts snapshots every selected component's transform at pick
+	// time, indexed the same as selection.Components.
+	dragStarts []componentStart
+
+	// dragAxes freezes axisVector(axisDirX/Y/Z) at pick time, so a live
+	// SpaceLocal rotation can't make the axis drift mid-drag.
+	dragAxes [3]mgl.Vec3
+
+	// dragViewNormal is ray.Direction at pick time; the view-facing plane
+	// normal for the uniform-scale handle.
+	dragViewNormal mgl.Vec3
+
+	// scaleStartDist is the signed distance from the pivot to the initial
+	// pick hit along dragAxes[axisDir] (or, for axisDirUniform, the unsigned
+	// distance within the view plane).
+	scaleStartDist float32
+
+	// startLocation snapshots the pivot's location at pick time; snap and
+	// orbit math is computed relative to this rather than compounded.
+	startLocation mgl.Vec3
+
+	// liveScaleFactor and liveAngle are the unsnapped, continuously
+	// updated scale factor/rotation angle for the current drag.
+	liveScaleFactor mgl.Vec3
+	liveAngle       float32
 }
 
 // CreateGizmo allocates a new gizmo and builds the renderable with the shader specified.
 // (Shader should support Vert & VertColor)
 func CreateGizmo(shader *fizzle.RenderShader) *Gizmo {
 	g := new(Gizmo)
+	g.shader = shader
 
 	// build the transform renderables
 	g.buildRenderables(shader)
 
 	// build the entity to render
 	g.Gizmo = scene.NewVisibleEntity()
+	g.mode = ModeTranslate
 	g.Gizmo.Renderable = g.translate
 	g.UpdateScale(1.0)
 
 	return g
 }
 
+// SetSelection assigns the set of components the gizmo operates on and
+// recomputes the pivot and colliders to match. The owning editor should call
+// this whenever the user's selection changes.
+func (g *Gizmo) SetSelection(selection *Selection) {
+	g.selection = selection
+	g.RecomputePivot()
+}
+
+// RecomputePivot recalculates the gizmo's pivot point from the current
+// selection according to its PivotMode, then repositions the gizmo
+// renderable and regenerates its colliders to match. The owning editor
+// should call this whenever the selection, or any selected component's
+// transform, changes outside of a drag already in progress.
+func (g *Gizmo) RecomputePivot() {
+	if g.selection == nil || len(g.selection.Components) == 0 {
+		g.pivot = mgl.Vec3{}
+	} else if g.selection.Pivot == PivotActive && g.selection.Active != nil {
+		g.pivot = g.selection.Active.Location
+	} else {
+		var sum mgl.Vec3
+		for _, c := range g.selection.Components {
+			sum = sum.Add(c.Location)
+		}
+		g.pivot = sum.Mul(1.0 / float32(len(g.selection.Components)))
+	}
+
+	g.Gizmo.Renderable.Location = g.pivot
+	g.Gizmo.Renderable.Orientation = g.localRotation()
+	g.generateColliders(g.lastScale)
+}
+
+// SetMode swaps the gizmo's active renderable and rebuilds the coarse
+// colliders to match the requested transform operation.
+func (g *Gizmo) SetMode(mode GizmoMode) {
+	g.mode = mode
+	switch mode {
+	case ModeRotate:
+		g.Gizmo.Renderable = g.rotate
+	case ModeScale:
+		g.Gizmo.Renderable = g.scale
+	default:
+		g.Gizmo.Renderable = g.translate
+	}
+	g.Gizmo.Renderable.Scale = mgl.Vec3{g.lastScale, g.lastScale, g.lastScale}
+	g.Gizmo.Renderable.Location = g.pivot
+	g.Gizmo.Renderable.Orientation = g.localRotation()
+	g.generateColliders(g.lastScale)
+}
+
+// SetSpace switches the gizmo between world-aligned and local-aligned axes
+// and regenerates its colliders to match. The owning editor should also call
+// this (or RecomputePivot) whenever the active component's orientation
+// changes while SpaceLocal is in effect, since the gizmo doesn't track that
+// on its own.
+func (g *Gizmo) SetSpace(space GizmoSpace) {
+	g.space = space
+	g.Gizmo.Renderable.Orientation = g.localRotation()
+	g.generateColliders(g.lastScale)
+}
+
+// localRotation returns the rotation currently applied to the gizmo's axes:
+// identity in SpaceWorld, or Selection.Active's orientation in SpaceLocal
+// (falling back to identity if there is no active component).
+func (g *Gizmo) localRotation() mgl.Quat {
+	if g.space == SpaceLocal && g.selection != nil && g.selection.Active != nil {
+		return g.selection.Active.Orientation
+	}
+	return mgl.QuatIdent()
+}
+
+// axisVector returns the world-space direction of local axis index axis
+// (axisDirX, axisDirY or axisDirZ), rotated into the gizmo's current space.
+// Building the axis line and rotation plane tests around this direction,
+// rather than the raw world axis, is what makes picking and dragging
+// respect SpaceLocal.
+func (g *Gizmo) axisVector(axis int) mgl.Vec3 {
+	var v mgl.Vec3
+	v[axis] = 1.0
+	return g.localRotation().Rotate(v)
+}
+
 // generateColliders creates the colliders at the correct scaled location
-// for the gizmo.
+// for the gizmo, matching whichever mode is currently active.
 func (g *Gizmo) generateColliders(scale float32) {
-	g.Gizmo.CoarseColliders = make([]glider.Collider, 0, 3)
+	switch g.mode {
+	case ModeRotate:
+		g.generateRotateColliders(scale)
+	case ModeScale:
+		g.generateScaleColliders(scale)
+	default:
+		g.generateTranslateColliders(scale)
+	}
+}
 
+// newSphereCollider builds a glider sphere collider centered on center.
+func newSphereCollider(center mgl.Vec3, radius float32) glider.Collider {
 	sphere := glider.NewSphere()
-	sphere.Radius = 0.05 * scale
-	sphere.Center = mgl.Vec3{0.9 * scale, 0.0, 0.0}
-	g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders, sphere)
+	sphere.Radius = radius
+	sphere.Center = center
+	return sphere
+}
+
+// newAABBCollider builds a glider AABB collider spanning center +/- half on
+// each axis.
+func newAABBCollider(center, half mgl.Vec3) glider.Collider {
+	box := glider.NewAABB()
+	box.Min = center.Sub(half)
+	box.Max = center.Add(half)
+	return box
+}
+
+// generateTranslateColliders places a pick sphere at the tip of each axis
+// handle, matching the arrowheads drawn by addTetrahedrons, plus a thin pick
+// box for each of the three plane handles drawn by addPlaneQuads. Handle
+// centers are rotated by localRotation and offset from the gizmo's pivot, so
+// they follow the renderable in both SpaceWorld and SpaceLocal. The pick
+// boxes themselves stay aligned with the world axes, so in SpaceLocal a
+// plane handle's pick region does not tilt with its drawn quad.
+func (g *Gizmo) generateTranslateColliders(scale float32) {
+	rot := g.localRotation()
+	g.Gizmo.CoarseColliders = make([]glider.Collider, 0, 6)
+
+	g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders,
+		newSphereCollider(g.pivot.Add(rot.Rotate(mgl.Vec3{0.9 * scale, 0.0, 0.0})), 0.05*scale),
+		newSphereCollider(g.pivot.Add(rot.Rotate(mgl.Vec3{0.0, 0.9 * scale, 0.0})), 0.05*scale),
+		newSphereCollider(g.pivot.Add(rot.Rotate(mgl.Vec3{0.0, 0.0, 0.9 * scale})), 0.05*scale),
+	)
 
-	sphere = glider.NewSphere()
-	sphere.Radius = 0.05 * scale
-	sphere.Center = mgl.Vec3{0.0, 0.9 * scale, 0.0}
-	g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders, sphere)
+	near := planeHandleNear * scale
+	far := planeHandleFar * scale
+	thickness := planeHandleThickness * scale
+	mid := (near + far) / 2.0
+	half := (far - near) / 2.0
 
-	sphere = glider.NewSphere()
-	sphere.Radius = 0.05 * scale
-	sphere.Center = mgl.Vec3{0.0, 0.0, 0.9 * scale}
-	g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders, sphere)
+	g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders,
+		newAABBCollider(g.pivot.Add(rot.Rotate(mgl.Vec3{mid, mid, 0.0})), mgl.Vec3{half, half, thickness}),
+		newAABBCollider(g.pivot.Add(rot.Rotate(mgl.Vec3{mid, 0.0, mid})), mgl.Vec3{half, thickness, half}),
+		newAABBCollider(g.pivot.Add(rot.Rotate(mgl.Vec3{0.0, mid, mid})), mgl.Vec3{thickness, half, half}),
+	)
+}
+
+// generateScaleColliders places a pick box near the end of each axis handle,
+// matching the squares drawn by addSquares, plus a center sphere that
+// triggers a uniform scale of all three axes at once. Handle centers are
+// rotated by localRotation and offset from the gizmo's pivot, so picking
+// lines up with the renderable in both SpaceWorld and SpaceLocal.
+func (g *Gizmo) generateScaleColliders(scale float32) {
+	const handle = 0.9
+	const half = 0.05
+
+	rot := g.localRotation()
+	g.Gizmo.CoarseColliders = make([]glider.Collider, 0, 4)
+
+	centers := []mgl.Vec3{
+		{handle * scale, 0.0, 0.0},
+		{0.0, handle * scale, 0.0},
+		{0.0, 0.0, handle * scale},
+	}
+	extent := mgl.Vec3{half * scale, half * scale, half * scale}
+	for _, center := range centers {
+		g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders,
+			newAABBCollider(g.pivot.Add(rot.Rotate(center)), extent))
+	}
+
+	g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders, newSphereCollider(g.pivot, 0.08*scale))
+}
+
+// generateRotateColliders builds one torusCollider per axis. Each one picks
+// against a band of radius 0.8 to 1.0 (times scale) around the pivot, in the
+// plane through the pivot perpendicular to the axis, so a drag can be picked
+// up anywhere around that circle. The band does not follow the short arcs
+// addToruses draws near each axis tip, which lie outside that plane. The
+// axis normal is rotated by localRotation so the picking plane lines up with
+// the renderable in SpaceLocal.
+func (g *Gizmo) generateRotateColliders(scale float32) {
+	const innerRadius = 0.8
+	const outerRadius = 1.0
+
+	g.Gizmo.CoarseColliders = make([]glider.Collider, 0, 3)
+	for _, axis := range []int{axisDirX, axisDirY, axisDirZ} {
+		g.Gizmo.CoarseColliders = append(g.Gizmo.CoarseColliders, &torusCollider{
+			normal:      g.axisVector(axis),
+			center:      g.pivot,
+			innerRadius: innerRadius * scale,
+			outerRadius: outerRadius * scale,
+		})
+	}
+}
 
-	for _, c := range g.Gizmo.CoarseColliders {
-		sphere := c.(*glider.Sphere)
-		fmt.Printf("Collider center: %v\n", sphere.Center)
+// torusCollider hit-tests a rotation ring by intersecting a ray with the
+// plane through center with the given normal, and checking whether the hit
+// point's distance from center falls within the ring's radius band.
+type torusCollider struct {
+	normal      mgl.Vec3
+	center      mgl.Vec3
+	innerRadius float32
+	outerRadius float32
+}
+
+// CollideVsRay implements glider.Collider.
+func (t *torusCollider) CollideVsRay(ray *glider.CollisionRay) (glider.CollisionStatus, float32) {
+	hit, hitDist, ok := intersectRayPlane(ray, t.center, t.normal)
+	if !ok {
+		return glider.NoIntersect, 0
 	}
 
+	radius := hit.Sub(t.center).Len()
+	if radius < t.innerRadius || radius > t.outerRadius {
+		return glider.NoIntersect, 0
+	}
+
+	return glider.Intersect, hitDist
+}
+
+// intersectRayPlane finds the point where ray crosses the plane defined by
+// planePoint and planeNormal, returning the hit point, the distance along
+// the ray to that point, and whether an intersection in front of the ray
+// origin was found.
+func intersectRayPlane(ray *glider.CollisionRay, planePoint, planeNormal mgl.Vec3) (mgl.Vec3, float32, bool) {
+	denom := planeNormal.Dot(ray.Direction)
+	if denom > -1e-6 && denom < 1e-6 {
+		return mgl.Vec3{}, 0, false
+	}
+
+	t := planePoint.Sub(ray.Origin).Dot(planeNormal) / denom
+	if t < 0 {
+		return mgl.Vec3{}, 0, false
+	}
+
+	return ray.Origin.Add(ray.Direction.Mul(t)), t, true
+}
+
+// SetSnaps configures the grid, angle (radians) and scale increments used
+// to quantize drags while SnapActive is true. Pass 0 for any of them to
+// leave that channel unsnapped even while SnapActive is set.
+func (g *Gizmo) SetSnaps(t, r, s float32) {
+	g.TranslateSnap = t
+	g.RotateSnap = r
+	g.ScaleSnap = s
+}
+
+// roundf32 rounds v to the nearest integer, away from zero on ties.
+func roundf32(v float32) float32 {
+	return float32(math.Round(float64(v)))
+}
+
+// buildSnapTicks renders faint tick marks along axis at TranslateSnap
+// intervals out to the edge of the gizmo, giving visual feedback about
+// where a translate drag will snap to. Returns nil if translation snapping
+// isn't configured.
+func (g *Gizmo) buildSnapTicks(axis int) *fizzle.Renderable {
+	if g.TranslateSnap <= 0 || g.shader == nil {
+		return nil
+	}
+
+	const tickHalf = 0.01
+	const tickAlpha = 0.25
+	const extent = float32(1.0)
+
+	var verts []float32
+	var indexes []uint32
+	var idxOffset uint32
+	var faceTotal uint32
+
+	// ticks are built in gizmo units and scaled by lastScale below, so step
+	// by the world-space snap increment divided by that scale
+	step := g.TranslateSnap / g.lastScale
+	for d := step; d < extent; d += step {
+		min := mgl.Vec3{-tickHalf, -tickHalf, -tickHalf}
+		max := mgl.Vec3{tickHalf, tickHalf, tickHalf}
+		min[axis] = d - tickHalf
+		max[axis] = d + tickHalf
+
+		verts, indexes, idxOffset = addAxisToVBO(min[0], max[0], min[1], max[1], min[2], max[2], 1.0, 1.0, 1.0, tickAlpha, verts, indexes, idxOffset)
+		faceTotal += 12
+	}
+
+	if len(verts) == 0 {
+		return nil
+	}
+
+	ticks := assembleIntoRenderable(verts, indexes, faceTotal)
+	ticks.Material.Shader = g.shader
+	ticks.Scale = mgl.Vec3{g.lastScale, g.lastScale, g.lastScale}
+	ticks.Location = g.pivot
+	ticks.Orientation = g.localRotation()
+	return ticks
 }
 
 // UpdateScale modifies the the gizmo renderable for the current frame.
@@ -98,11 +529,16 @@ func (g *Gizmo) UpdateScale(scale float32) {
 	g.lastScale = scale
 }
 
-// OnLMBDown should be called by the owning component when the left mouse
-// button is detected to be down. The Gizmo type will then take care of
-// tracking state for the mouse positions. The coordinate [mx, my] should
-// be normalized for screen size (divided by width and height).
-func (g *Gizmo) OnLMBDown(mx, my float32, ray *glider.CollisionRay, active *component.Component) {
+// OnLMBDown should be called by the owning editor when the left mouse button
+// is detected to be down. The Gizmo type will then take care of tracking
+// state for the mouse positions and applying the drag to every component in
+// the current Selection. The coordinate [mx, my] should be normalized for
+// screen size (divided by width and height).
+func (g *Gizmo) OnLMBDown(mx, my float32, ray *glider.CollisionRay) {
+	if g.selection == nil || len(g.selection.Components) == 0 {
+		return
+	}
+
 	if g.mouseDown == false {
 		// if this is our first mouse down, reset the mouse position
 		// tracking and test against axis handles
@@ -116,6 +552,37 @@ func (g *Gizmo) OnLMBDown(mx, my float32, ray *glider.CollisionRay, active *comp
 				g.lastMouseX = mx
 				g.lastMouseY = my
 				g.axisDir = axisNum
+
+				g.startLocation = g.pivot
+				g.liveScaleFactor = mgl.Vec3{1.0, 1.0, 1.0}
+				g.liveAngle = 0.0
+				g.dragAxes = [3]mgl.Vec3{g.axisVector(axisDirX), g.axisVector(axisDirY), g.axisVector(axisDirZ)}
+				g.dragViewNormal = ray.Direction
+
+				g.dragStarts = g.dragStarts[:0]
+				for _, c := range g.selection.Components {
+					g.dragStarts = append(g.dragStarts, componentStart{
+						component:   c,
+						location:    c.Location,
+						orientation: c.Orientation,
+						scale:       c.Scale,
+					})
+				}
+
+				if g.mode == ModeRotate {
+					g.lastHitVec = g.rotationPlaneHit(g.axisDir, ray)
+				}
+				if g.mode == ModeTranslate {
+					if hit, ok := g.dragHit(ray); ok {
+						g.pickOffset = hit.Sub(g.startLocation)
+					}
+					if g.SnapActive && g.axisDir <= axisDirZ {
+						g.SnapTicks = g.buildSnapTicks(g.axisDir)
+					}
+				}
+				if g.mode == ModeScale {
+					g.scaleStartDist = g.scaleAxisDistance(ray)
+				}
 				break
 			}
 		}
@@ -134,23 +601,244 @@ func (g *Gizmo) OnLMBDown(mx, my float32, ray *glider.CollisionRay, active *comp
 		return
 	}
 
-	// FIXME: for now, just use diffX to run the transform
-	// FIXME: do more than translate
-	diffX := g.lastScale * 10.0 * (g.lastMouseX - mx)
-	var axisDir mgl.Vec3
-	axisDir[g.axisDir] = 1.0
-	diffDir := axisDir.Mul(diffX)
-	active.Location = active.Location.Add(diffDir)
+	switch g.mode {
+	case ModeRotate:
+		g.applyRotate(ray)
+	case ModeScale:
+		g.applyScale(ray)
+	default:
+		g.applyTranslate(ray)
+	}
 
 	// update the trackers before returning
 	g.lastMouseX = mx
 	g.lastMouseY = my
 }
 
-// OnLMBUp should be called by the owning component with the left mouse
-// button is detected to be up.
+// applyTranslate re-casts the mouse ray against the picked axis line or
+// plane and moves the pivot so the point grabbed at pick time (offset by
+// pickOffset) stays under the cursor, quantizing to TranslateSnap relative
+// to the drag's start location when SnapActive is set. The same delta is
+// added to every selected component's start location; translation doesn't
+// depend on Selection.Pivot since it doesn't orbit.
+func (g *Gizmo) applyTranslate(ray *glider.CollisionRay) {
+	hit, ok := g.dragHit(ray)
+	if !ok {
+		return
+	}
+
+	newPivot := g.snapLocation(hit.Sub(g.pickOffset))
+	delta := newPivot.Sub(g.startLocation)
+	for _, cs := range g.dragStarts {
+		cs.component.Location = cs.location.Add(delta)
+	}
+}
+
+// snapLocation quantizes liveLoc relative to startLocation by
+// TranslateSnap when SnapActive is set; otherwise it returns liveLoc
+// unchanged.
+func (g *Gizmo) snapLocation(liveLoc mgl.Vec3) mgl.Vec3 {
+	if !g.SnapActive || g.TranslateSnap <= 0 {
+		return liveLoc
+	}
+
+	delta := liveLoc.Sub(g.startLocation)
+	for i := 0; i < 3; i++ {
+		delta[i] = roundf32(delta[i]/g.TranslateSnap) * g.TranslateSnap
+	}
+	return g.startLocation.Add(delta)
+}
+
+// dragHit re-casts ray against whichever axis line or plane g.axisDir
+// refers to, anchored at the drag's start location, returning the current
+// world-space hit point. Axis handles are projected against the closest
+// point on the infinite axis line; plane handles are intersected against the
+// plane directly. Axis and plane-normal directions come from dragAxes
+// (axisVector frozen at pick time), so the drag is constrained to the
+// gizmo's own axes in SpaceLocal rather than always the world axes.
+func (g *Gizmo) dragHit(ray *glider.CollisionRay) (mgl.Vec3, bool) {
+	switch g.axisDir {
+	case axisDirX:
+		return closestPointOnLine(ray, g.startLocation, g.dragAxes[axisDirX]), true
+	case axisDirY:
+		return closestPointOnLine(ray, g.startLocation, g.dragAxes[axisDirY]), true
+	case axisDirZ:
+		return closestPointOnLine(ray, g.startLocation, g.dragAxes[axisDirZ]), true
+	case axisDirXY:
+		hit, _, ok := intersectRayPlane(ray, g.startLocation, g.dragAxes[axisDirZ])
+		return hit, ok
+	case axisDirXZ:
+		hit, _, ok := intersectRayPlane(ray, g.startLocation, g.dragAxes[axisDirY])
+		return hit, ok
+	case axisDirYZ:
+		hit, _, ok := intersectRayPlane(ray, g.startLocation, g.dragAxes[axisDirX])
+		return hit, ok
+	}
+	return mgl.Vec3{}, false
+}
+
+// closestPointOnLine finds the point on the infinite line through linePoint
+// in direction lineDir that lies closest to ray, using the standard
+// closest-points-between-two-lines construction.
+func closestPointOnLine(ray *glider.CollisionRay, linePoint, lineDir mgl.Vec3) mgl.Vec3 {
+	r := ray.Origin.Sub(linePoint)
+	a := ray.Direction.Dot(ray.Direction)
+	e := lineDir.Dot(lineDir)
+	f := lineDir.Dot(r)
+	b := ray.Direction.Dot(lineDir)
+	c := ray.Direction.Dot(r)
+
+	denom := a*e - b*b
+	if denom > -1e-6 && denom < 1e-6 {
+		// ray is parallel to the line; fall back to projecting the ray
+		// origin onto it.
+		return linePoint.Add(lineDir.Mul(f / e))
+	}
+
+	t := (a*f - b*c) / denom
+	return linePoint.Add(lineDir.Mul(t))
+}
+
+// scaleAxisDistance projects ray onto the picked axis and returns the signed
+// distance of that point from the pivot along the axis; for axisDirUniform it
+// intersects the view-facing plane through startLocation instead and returns
+// the unsigned distance of the hit from the pivot. The result is compared
+// against scaleStartDist.
+func (g *Gizmo) scaleAxisDistance(ray *glider.CollisionRay) float32 {
+	if g.axisDir == axisDirUniform {
+		hit, _, ok := intersectRayPlane(ray, g.startLocation, g.dragViewNormal)
+		if !ok {
+			return g.scaleStartDist
+		}
+		return hit.Sub(g.startLocation).Len()
+	}
+
+	axis := g.dragAxes[g.axisDir]
+	hit := closestPointOnLine(ray, g.startLocation, axis)
+	return hit.Sub(g.startLocation).Dot(axis)
+}
+
+// applyScale re-projects the ray onto the picked axis and compares it
+// against scaleStartDist to get a camera-independent factor, then rescales
+// every selected component (and, unless the selection pivots individually,
+// orbits it around the pivot by the same factor).
+func (g *Gizmo) applyScale(ray *glider.CollisionRay) {
+	factor := float32(1.0)
+	if g.scaleStartDist > 1e-6 || g.scaleStartDist < -1e-6 {
+		factor = g.scaleAxisDistance(ray) / g.scaleStartDist
+	}
+
+	if g.axisDir == axisDirUniform {
+		g.liveScaleFactor = mgl.Vec3{factor, factor, factor}
+	} else {
+		g.liveScaleFactor = mgl.Vec3{1.0, 1.0, 1.0}
+		g.liveScaleFactor[g.axisDir] = factor
+	}
+
+	individual := g.selection.Pivot == PivotIndividualOrigins
+	rot := g.localRotation()
+	invRot := rot.Inverse()
+	for _, cs := range g.dragStarts {
+		liveScale := mgl.Vec3{
+			cs.scale[0] * g.liveScaleFactor[0],
+			cs.scale[1] * g.liveScaleFactor[1],
+			cs.scale[2] * g.liveScaleFactor[2],
+		}
+		cs.component.Scale = g.snapScale(liveScale, cs.scale)
+
+		if !individual {
+			offset := invRot.Rotate(cs.location.Sub(g.startLocation))
+			offset = mgl.Vec3{
+				offset[0] * g.liveScaleFactor[0],
+				offset[1] * g.liveScaleFactor[1],
+				offset[2] * g.liveScaleFactor[2],
+			}
+			cs.component.Location = g.startLocation.Add(rot.Rotate(offset))
+		}
+	}
+}
+
+// snapScale quantizes liveScale relative to startScale by ScaleSnap when
+// SnapActive is set; otherwise it returns liveScale unchanged.
+func (g *Gizmo) snapScale(liveScale, startScale mgl.Vec3) mgl.Vec3 {
+	if !g.SnapActive || g.ScaleSnap <= 0 {
+		return liveScale
+	}
+
+	snapped := liveScale
+	for i := 0; i < 3; i++ {
+		delta := liveScale[i] - startScale[i]
+		snapped[i] = startScale[i] + roundf32(delta/g.ScaleSnap)*g.ScaleSnap
+	}
+	return snapped
+}
+
+// applyRotate intersects the ray with the rotation plane for the picked axis
+// and accumulates the signed angle into liveAngle. Every selected
+// component's orientation is re-derived from its start orientation plus the
+// (optionally snapped) total angle; unless the selection pivots
+// individually, each component also orbits the pivot by the same rotation.
+func (g *Gizmo) applyRotate(ray *glider.CollisionRay) {
+	hit := g.rotationPlaneHit(g.axisDir, ray)
+	if hit.Len() < 1e-6 || g.lastHitVec.Len() < 1e-6 {
+		g.lastHitVec = hit
+		return
+	}
+
+	axis := g.dragAxes[g.axisDir]
+
+	from := g.lastHitVec.Normalize()
+	to := hit.Normalize()
+
+	cosAngle := from.Dot(to)
+	if cosAngle > 1.0 {
+		cosAngle = 1.0
+	} else if cosAngle < -1.0 {
+		cosAngle = -1.0
+	}
+	angle := float32(math.Acos(float64(cosAngle)))
+	if from.Cross(to).Dot(axis) < 0.0 {
+		angle = -angle
+	}
+
+	g.liveAngle += angle
+	g.lastHitVec = hit
+
+	appliedAngle := g.liveAngle
+	if g.SnapActive && g.RotateSnap > 0 {
+		appliedAngle = roundf32(g.liveAngle/g.RotateSnap) * g.RotateSnap
+	}
+
+	delta := mgl.QuatRotate(appliedAngle, axis)
+	individual := g.selection.Pivot == PivotIndividualOrigins
+	for _, cs := range g.dragStarts {
+		cs.component.Orientation = delta.Mul(cs.orientation).Normalize()
+
+		if !individual {
+			offset := cs.location.Sub(g.startLocation)
+			cs.component.Location = g.startLocation.Add(delta.Rotate(offset))
+		}
+	}
+}
+
+// rotationPlaneHit intersects ray with the plane perpendicular to
+// dragAxes[axis] that passes through the drag's start pivot
+// (g.startLocation), returning the hit point relative to that pivot, or the
+// zero vector if the ray is parallel to the plane.
+func (g *Gizmo) rotationPlaneHit(axis int, ray *glider.CollisionRay) mgl.Vec3 {
+	hit, _, ok := intersectRayPlane(ray, g.startLocation, g.dragAxes[axis])
+	if !ok {
+		return mgl.Vec3{}
+	}
+	return hit.Sub(g.startLocation)
+}
+
+// OnLMBUp should be called by the owning editor when the left mouse button
+// is detected to be up.
 func (g *Gizmo) OnLMBUp() {
 	g.mouseDown = false
+	g.SnapTicks = nil
+	g.dragStarts = nil
 }
 
 func addAxisToVBO(xmin, xmax, ymin, ymax, zmin, zmax, r, g, b, a float32, verts []float32, indexes []uint32, idxOffset uint32) ([]float32, []uint32, uint32) {
@@ -485,6 +1173,22 @@ func addToruses(verts []float32, indexes []uint32, idxOffset uint32, faceTotal u
 	return verts, indexes, idxOffset + 63, faceTotal + uint32(len(idxPattern)/3)
 }
 
+// addPlaneQuads draws the three translate plane handles as thin,
+// semi-transparent boxes positioned to match the pick regions built by
+// generateTranslateColliders.
+func addPlaneQuads(verts []float32, indexes []uint32, idxOffset uint32, faceTotal uint32, a float32) ([]float32, []uint32, uint32, uint32) {
+	near := planeHandleNear
+	far := planeHandleFar
+	thickness := planeHandleThickness
+	planeAlpha := a * 0.4
+
+	verts, indexes, idxOffset = addAxisToVBO(near, far, near, far, -thickness, thickness, 0.0, 1.0, 1.0, planeAlpha, verts, indexes, idxOffset) // xy plane / cyan
+	verts, indexes, idxOffset = addAxisToVBO(near, far, -thickness, thickness, near, far, 1.0, 0.0, 1.0, planeAlpha, verts, indexes, idxOffset) // xz plane / magenta
+	verts, indexes, idxOffset = addAxisToVBO(-thickness, thickness, near, far, near, far, 1.0, 1.0, 0.0, planeAlpha, verts, indexes, idxOffset) // yz plane / yellow
+
+	return verts, indexes, idxOffset, faceTotal + 12*3
+}
+
 func (g *Gizmo) buildRenderables(shader *fizzle.RenderShader) {
 	const axisFaceCount = 12 * 3
 	const alpha = 0.5
@@ -492,6 +1196,7 @@ func (g *Gizmo) buildRenderables(shader *fizzle.RenderShader) {
 	// build the translate gizmo
 	verts, indexes, idxOffset, faceTotal := buildAxisSet(alpha)
 	verts, indexes, idxOffset, faceTotal = addTetrahedrons(verts, indexes, idxOffset, faceTotal, alpha)
+	verts, indexes, idxOffset, faceTotal = addPlaneQuads(verts, indexes, idxOffset, faceTotal, alpha)
 	g.translate = assembleIntoRenderable(verts, indexes, faceTotal)
 	g.translate.Material.Shader = shader
 
@@ -506,4 +1211,4 @@ func (g *Gizmo) buildRenderables(shader *fizzle.RenderShader) {
 	verts, indexes, idxOffset, faceTotal = addToruses(verts, indexes, idxOffset, faceTotal, alpha)
 	g.rotate = assembleIntoRenderable(verts, indexes, faceTotal)
 	g.rotate.Material.Shader = shader
-}
\ No newline at end of file
+}